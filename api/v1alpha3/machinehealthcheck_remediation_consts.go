@@ -0,0 +1,28 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+// RemediationInProgressCondition is set on a Machine while its
+// MachineHealthCheck is attempting an in-place remediation strategy
+// (PowerCycle or Reimage) on it, before any escalation to Replace.
+const RemediationInProgressCondition ConditionType = "RemediationInProgress"
+
+// RemediationEscalatedCondition is set on a Machine once its
+// MachineHealthCheck's escalation policy has exhausted in-place remediation
+// attempts within the configured window and fallen back to the default
+// Replace behavior (marking MachineOwnerRemediatedCondition false).
+const RemediationEscalatedCondition ConditionType = "RemediationEscalated"
@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// RemediationStrategyType is the remediation strategy a MachineHealthCheck
+// applies to its unhealthy targets.
+// +kubebuilder:validation:Enum=Replace;PowerCycle;Reimage;Escalate
+type RemediationStrategyType string
+
+const (
+	// RemediationStrategyReplace marks the target's
+	// MachineOwnerRemediatedCondition false, the default behavior where the
+	// owning controller deletes and replaces the Machine.
+	RemediationStrategyReplace RemediationStrategyType = "Replace"
+
+	// RemediationStrategyPowerCycle requests that the infrastructure
+	// provider power-cycle the underlying host without deleting the
+	// Machine. Useful on bare metal where the "VM" is a physical host.
+	RemediationStrategyPowerCycle RemediationStrategyType = "PowerCycle"
+
+	// RemediationStrategyReimage requests that the infrastructure provider
+	// reimage the underlying host without deleting the Machine.
+	RemediationStrategyReimage RemediationStrategyType = "Reimage"
+
+	// RemediationStrategyEscalate behaves like RemediationStrategyPowerCycle,
+	// but after a configured number of in-place attempts within a configured
+	// window, gives up and falls back to RemediationStrategyReplace.
+	RemediationStrategyEscalate RemediationStrategyType = "Escalate"
+)
+
+// UnhealthyCondition represents a Node condition type and value, with a
+// timeout, that determines whether a Machine is unhealthy.
+type UnhealthyCondition struct {
+	// Type of Node condition.
+	Type corev1.NodeConditionType `json:"type"`
+
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+
+	// Timeout is the duration that the Node must match Type and Status
+	// before the Machine is considered unhealthy.
+	Timeout metav1.Duration `json:"timeout"`
+}
+
+// MachineHealthCheckSpec defines the desired state of MachineHealthCheck.
+type MachineHealthCheckSpec struct {
+	// ClusterName is the name of the Cluster this MachineHealthCheck belongs to.
+	ClusterName string `json:"clusterName"`
+
+	// Selector matches the Machines this MachineHealthCheck monitors.
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// UnhealthyConditions lists the Node conditions that determine whether
+	// a Node (and so its Machine) is considered unhealthy.
+	// +optional
+	UnhealthyConditions []UnhealthyCondition `json:"unhealthyConditions,omitempty"`
+
+	// MaxUnhealthy short-circuits remediation when the number (or
+	// percentage) of unhealthy targets exceeds this value.
+	// +optional
+	MaxUnhealthy *intstr.IntOrString `json:"maxUnhealthy,omitempty"`
+
+	// NodeStartupTimeout is how long a Machine can have no Node before it
+	// is considered unhealthy.
+	// +optional
+	NodeStartupTimeout *metav1.Duration `json:"nodeStartupTimeout,omitempty"`
+
+	// RemediationStrategy picks how unhealthy targets are remediated.
+	// Defaults to RemediationStrategyReplace. Targets handled by a gRPC
+	// RemediationProvider (see RemediationProviderAnnotation) ignore this
+	// field, unless the call to the provider fails.
+	// +optional
+	RemediationStrategy *RemediationStrategyType `json:"remediationStrategy,omitempty"`
+}
+
+// MachineHealthCheckStatus defines the observed state of MachineHealthCheck.
+type MachineHealthCheckStatus struct {
+	// ExpectedMachines is the number of machines currently monitored.
+	// +optional
+	ExpectedMachines int32 `json:"expectedMachines"`
+
+	// CurrentHealthy is the number of currently healthy machines.
+	// +optional
+	CurrentHealthy int32 `json:"currentHealthy"`
+
+	// Targets lists the names of the Machines currently monitored.
+	// +optional
+	Targets []string `json:"targets,omitempty"`
+
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions defines the current state of the MachineHealthCheck.
+	// +optional
+	Conditions Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=machinehealthchecks,shortName=mhc;mhcs,scope=Namespaced,categories=cluster-api
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// MachineHealthCheck is the Schema for the machinehealthchecks API.
+type MachineHealthCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MachineHealthCheckSpec   `json:"spec,omitempty"`
+	Status MachineHealthCheckStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MachineHealthCheckList contains a list of MachineHealthCheck.
+type MachineHealthCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MachineHealthCheck `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MachineHealthCheck{}, &MachineHealthCheckList{})
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProviderConfigFinalizer is set on a ProviderConfig to ensure the manager
+// closes its remediation provider connection before it is removed.
+const ProviderConfigFinalizer = "providerconfig.cluster.x-k8s.io"
+
+// ProviderConfigSpec defines the desired state of ProviderConfig.
+type ProviderConfigSpec struct {
+	// Endpoint is the host:port of the remediation provider's gRPC service.
+	// +kubebuilder:validation:MinLength=1
+	Endpoint string `json:"endpoint"`
+
+	// CASecretRef references a Secret containing a "ca.crt" key used to
+	// verify the provider's server certificate. If omitted, the host's root
+	// CA set is used.
+	// +optional
+	CASecretRef *corev1.SecretReference `json:"caSecretRef,omitempty"`
+
+	// Insecure disables TLS when dialing the provider. It should only be used
+	// for local development and testing.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// Timeout bounds every ShouldRemediate/Remediate call made to the
+	// provider. Defaults to 5s if not set.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ProviderConfigStatus defines the observed state of ProviderConfig.
+type ProviderConfigStatus struct {
+	// Ready is true once the manager has successfully dialed Endpoint.
+	// +optional
+	Ready bool `json:"ready,omitempty"`
+
+	// FailureReason is set when the manager could not dial Endpoint.
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=providerconfigs,scope=Namespaced,categories=cluster-api
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".spec.endpoint"
+// +kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+
+// ProviderConfig registers an out-of-tree remediation provider's gRPC
+// endpoint so MachineHealthCheck can resolve it by name.
+type ProviderConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProviderConfigSpec   `json:"spec,omitempty"`
+	Status ProviderConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProviderConfigList contains a list of ProviderConfig.
+type ProviderConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProviderConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ProviderConfig{}, &ProviderConfigList{})
+}
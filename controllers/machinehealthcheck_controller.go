@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/controllers/remediation"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
@@ -54,6 +55,21 @@ const (
 	// EventRemediationRestricted is emitted in case when machine remediation
 	// is restricted by remediation circuit shorting logic
 	EventRemediationRestricted string = "RemediationRestricted"
+
+	// EventExternalRemediationRestricted is emitted when a gRPC remediation
+	// provider's own ShouldRemediate circuit-breaker declines to remediate a
+	// target right now.
+	EventExternalRemediationRestricted string = "ExternalRemediationRestricted"
+
+	// EventExternalRemediationFailed is emitted when a gRPC remediation
+	// provider could not be reached or returned an error.
+	EventExternalRemediationFailed string = "ExternalRemediationFailed"
+
+	// RemediationProviderAnnotation, when set on a MachineHealthCheck, names
+	// the ProviderConfig whose gRPC endpoint should decide and perform
+	// remediation of this MachineHealthCheck's unhealthy targets, instead of
+	// the default "mark MachineOwnerRemediatedCondition false" behavior.
+	RemediationProviderAnnotation = "machinehealthcheck.cluster.x-k8s.io/remediation-provider"
 )
 
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;patch
@@ -67,12 +83,25 @@ type MachineHealthCheckReconciler struct {
 	Log     logr.Logger
 	Tracker *remote.ClusterCacheTracker
 
+	// RemediationProviders resolves the ProviderConfig name in a
+	// MachineHealthCheck's RemediationProviderAnnotation to a dialed
+	// out-of-tree remediation provider. It must be the same *remediation.Registry
+	// instance given to ProviderConfigReconciler.Registry, so that a
+	// ProviderConfig reconcile is visible here without a second dial. SetupWithManager
+	// returns an error if it is nil; it is never constructed here, because a
+	// private Registry would silently never see ProviderConfigReconciler's updates.
+	RemediationProviders *remediation.Registry
+
 	controller controller.Controller
 	recorder   record.EventRecorder
 	scheme     *runtime.Scheme
 }
 
 func (r *MachineHealthCheckReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
+	if r.RemediationProviders == nil {
+		return errors.New("RemediationProviders must be set to the same *remediation.Registry given to ProviderConfigReconciler.Registry")
+	}
+
 	controller, err := ctrl.NewControllerManagedBy(mgr).
 		For(&clusterv1.MachineHealthCheck{}).
 		Watches(
@@ -211,7 +240,7 @@ func (r *MachineHealthCheckReconciler) reconcile(ctx context.Context, logger log
 	m.Status.CurrentHealthy = int32(len(healthy))
 
 	// check MHC current health against MaxUnhealthy
-	if !isAllowedRemediation(m) {
+	if !isAllowedRemediation(m, unhealthy) {
 		logger.V(3).Info(
 			"Short-circuiting remediation",
 			"total target", totalTargets,
@@ -243,10 +272,34 @@ func (r *MachineHealthCheckReconciler) reconcile(ctx context.Context, logger log
 	)
 
 	// mark for remediation
+	provider, hasProvider := r.resolveRemediationProvider(m)
 	errList := []error{}
 	for _, t := range unhealthy {
 		logger.V(3).Info("Target meets unhealthy criteria, triggers remediation", "target", t.string())
 
+		if hasProvider {
+			requeueAfter, err := r.remediateExternally(ctx, logger, provider, m, t)
+			if err == nil {
+				if requeueAfter > 0 {
+					nextCheckTimes = append(nextCheckTimes, requeueAfter)
+				}
+				continue
+			}
+			logger.Error(err, "External remediation failed, falling back to default remediation", "target", t.string())
+			r.recorder.Eventf(t.Machine, corev1.EventTypeWarning, EventExternalRemediationFailed, "%v", err)
+		}
+
+		requeueAfter, handled, err := r.remediateWithStrategy(ctx, logger, m, t)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if handled {
+			if requeueAfter > 0 {
+				nextCheckTimes = append(nextCheckTimes, requeueAfter)
+			}
+			continue
+		}
+
 		conditions.MarkFalse(t.Machine, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediation, clusterv1.ConditionSeverityWarning, "MachineHealthCheck failed")
 		if err := t.patchHelper.Patch(ctx, t.Machine); err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "Failed to patch unhealthy machine status for machine %q", t.Machine.Name)
@@ -413,9 +466,109 @@ func (r *MachineHealthCheckReconciler) indexMachineByNodeName(object runtime.Obj
 	return nil
 }
 
+// resolveRemediationProvider looks up the gRPC remediation provider named by
+// m's RemediationProviderAnnotation, if any.
+func (r *MachineHealthCheckReconciler) resolveRemediationProvider(m *clusterv1.MachineHealthCheck) (remediation.Provider, bool) {
+	if r.RemediationProviders == nil {
+		return nil, false
+	}
+	name, ok := m.Annotations[RemediationProviderAnnotation]
+	if !ok || name == "" {
+		return nil, false
+	}
+	provider, ok := r.RemediationProviders.Get(name)
+	if !ok {
+		r.Log.Error(errors.Errorf("no such provider"), "RemediationProvider annotation names an unknown ProviderConfig", "provider", name)
+		return nil, false
+	}
+	return provider, true
+}
+
+// remediateExternally delegates the remediation decision and action for t to
+// provider. A zero duration with a nil error means the target was fully
+// handled (remediated, or the provider explicitly chose to ignore it) and
+// needs no default handling; a non-zero duration asks the caller to requeue
+// after that long. A non-nil error means the caller falls back to the
+// MachineHealthCheck's own remediation: its RemediationStrategy, and
+// otherwise the default "mark MachineOwnerRemediatedCondition false".
+func (r *MachineHealthCheckReconciler) remediateExternally(ctx context.Context, logger logr.Logger, provider remediation.Provider, m *clusterv1.MachineHealthCheck, t healthCheckTarget) (time.Duration, error) {
+	target := remediation.UnhealthyTarget{
+		ClusterName:     m.Spec.ClusterName,
+		Namespace:       t.Machine.Namespace,
+		MachineName:     t.Machine.Name,
+		UnhealthyReason: unhealthyReason(m, t),
+	}
+	if t.Machine.Status.NodeRef != nil {
+		target.NodeName = t.Machine.Status.NodeRef.Name
+	}
+
+	decision, err := provider.ShouldRemediate(ctx, target)
+	if err != nil {
+		return 0, errors.Wrap(err, "ShouldRemediate call to remediation provider failed")
+	}
+	if !decision.Allow {
+		logger.V(3).Info("External remediation provider declined to remediate target", "target", t.string(), "reason", decision.Reason)
+		r.recorder.Eventf(t.Machine, corev1.EventTypeWarning, EventExternalRemediationRestricted, "%v", decision.Reason)
+		return decision.Backoff, nil
+	}
+
+	result, err := provider.Remediate(ctx, target)
+	if err != nil {
+		return 0, errors.Wrap(err, "Remediate call to remediation provider failed")
+	}
+
+	// MachineOwnerRemediatedCondition=False is the signal the owning
+	// controller (MachineSet/KubeadmControlPlane) watches to delete and
+	// replace the Machine; only set it when the provider actually deleted
+	// the Machine. For an in-place action (Reboot/Reimage) or no action at
+	// all (Ignore), doing so would race the provider's own remediation and
+	// have the owner replace the Machine out from under it.
+	switch result.Action {
+	case remediation.ActionDelete:
+		conditions.MarkFalse(t.Machine, clusterv1.MachineOwnerRemediatedCondition, clusterv1.ConditionReason(result.Action), clusterv1.ConditionSeverityWarning, "Remediated externally by provider")
+	case remediation.ActionReboot, remediation.ActionReimage:
+		conditions.MarkTrue(t.Machine, clusterv1.RemediationInProgressCondition)
+	}
+	if err := t.patchHelper.Patch(ctx, t.Machine); err != nil {
+		return 0, errors.Wrapf(err, "Failed to patch machine status for machine %q after external remediation", t.Machine.Name)
+	}
+	r.recorder.Eventf(
+		t.Machine,
+		corev1.EventTypeNormal,
+		EventMachineMarkedUnhealthy,
+		"External remediation provider took action %q on machine %v",
+		result.Action,
+		t.string(),
+	)
+	return result.RequeueAfter, nil
+}
+
+// unhealthyReason reports why t is unhealthy, so an external remediation
+// provider can distinguish remediation causes (e.g. NodeNotReady vs a Node
+// that never appeared) instead of receiving the same opaque request for
+// every target. It matches t.Node's conditions against
+// m.Spec.UnhealthyConditions the same way healthCheckTargets decided t was
+// unhealthy in the first place.
+func unhealthyReason(m *clusterv1.MachineHealthCheck, t healthCheckTarget) string {
+	if t.Node == nil {
+		return "NodeStartupTimeout"
+	}
+	for _, c := range m.Spec.UnhealthyConditions {
+		for _, nodeCondition := range t.Node.Status.Conditions {
+			if nodeCondition.Type == c.Type && nodeCondition.Status == c.Status {
+				return string(c.Type)
+			}
+		}
+	}
+	return "Unknown"
+}
+
 // isAllowedRemediation checks the value of the MaxUnhealthy field to determine
-// whether remediation should be allowed or not
-func isAllowedRemediation(mhc *clusterv1.MachineHealthCheck) bool {
+// whether remediation should be allowed or not. Targets already undergoing an
+// in-place remediation (RemediationInProgressCondition) don't count toward
+// the MaxUnhealthy short-circuit, so a single stuck host being power-cycled
+// or reimaged doesn't block remediation of the rest of the fleet.
+func isAllowedRemediation(mhc *clusterv1.MachineHealthCheck, unhealthy []healthCheckTarget) bool {
 	if mhc.Spec.MaxUnhealthy == nil {
 		return true
 	}
@@ -424,9 +577,16 @@ func isAllowedRemediation(mhc *clusterv1.MachineHealthCheck) bool {
 		return false
 	}
 
+	inProgress := 0
+	for _, t := range unhealthy {
+		if conditions.IsTrue(t.Machine, clusterv1.RemediationInProgressCondition) {
+			inProgress++
+		}
+	}
+
 	// If unhealthy is above maxUnhealthy, short circuit any further remediation
-	unhealthy := mhc.Status.ExpectedMachines - mhc.Status.CurrentHealthy
-	return int(unhealthy) <= maxUnhealthy
+	unhealthyCount := int(mhc.Status.ExpectedMachines-mhc.Status.CurrentHealthy) - inProgress
+	return unhealthyCount <= maxUnhealthy
 }
 
 func machineNames(machines []*clusterv1.Machine) []string {
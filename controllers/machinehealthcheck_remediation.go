@@ -0,0 +1,186 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// EscalationMaxAttemptsAnnotation overrides defaultEscalationMaxAttempts
+	// for RemediationStrategyEscalate.
+	EscalationMaxAttemptsAnnotation = "machinehealthcheck.cluster.x-k8s.io/escalation-max-attempts"
+
+	// EscalationWindowAnnotation overrides defaultEscalationWindow for
+	// RemediationStrategyEscalate. Its value must parse with time.ParseDuration.
+	EscalationWindowAnnotation = "machinehealthcheck.cluster.x-k8s.io/escalation-window"
+
+	defaultEscalationMaxAttempts = 3
+	defaultEscalationWindow      = time.Hour
+
+	// MachineRemediationAttemptsAnnotation counts the in-place remediation
+	// attempts made on a Machine since the last one outside
+	// EscalationWindowAnnotation.
+	MachineRemediationAttemptsAnnotation = "mhc.cluster.x-k8s.io/remediation-attempts"
+
+	// MachineRemediationLastAttemptAnnotation is the RFC3339 timestamp of the
+	// last in-place remediation attempt made on a Machine.
+	MachineRemediationLastAttemptAnnotation = "mhc.cluster.x-k8s.io/last-attempt-time"
+
+	// MachineRemediationLastActionAnnotation is the last in-place remediation
+	// action (RemediationStrategyPowerCycle or RemediationStrategyReimage)
+	// requested for a Machine.
+	MachineRemediationLastActionAnnotation = "mhc.cluster.x-k8s.io/last-action"
+
+	// MachineRemediateActionAnnotation is the in-place remediation action
+	// currently requested of the infrastructure provider for this Machine.
+	// Infrastructure providers that support in-place remediation watch for
+	// this annotation and remove it once the action has been carried out.
+	MachineRemediateActionAnnotation = "mhc.cluster.x-k8s.io/remediate-action"
+)
+
+// remediateWithStrategy applies m's Spec.RemediationStrategy to t, if it
+// names an in-place strategy. handled is false when the target should fall
+// through to the default Replace remediation, either because no in-place
+// strategy is configured, the configured value isn't one we recognize, or
+// because an Escalate strategy has exhausted its attempts.
+func (r *MachineHealthCheckReconciler) remediateWithStrategy(ctx context.Context, logger logr.Logger, m *clusterv1.MachineHealthCheck, t healthCheckTarget) (requeueAfter time.Duration, handled bool, reterr error) {
+	var strategy clusterv1.RemediationStrategyType
+	if m.Spec.RemediationStrategy != nil {
+		strategy = *m.Spec.RemediationStrategy
+	}
+
+	var action clusterv1.RemediationStrategyType
+	switch strategy {
+	case clusterv1.RemediationStrategyPowerCycle:
+		action = clusterv1.RemediationStrategyPowerCycle
+	case clusterv1.RemediationStrategyReimage:
+		action = clusterv1.RemediationStrategyReimage
+	case clusterv1.RemediationStrategyEscalate:
+		action = clusterv1.RemediationStrategyPowerCycle
+	case "", clusterv1.RemediationStrategyReplace:
+		return 0, false, nil
+	default:
+		// Spec.RemediationStrategy is kubebuilder-enum-validated, so this
+		// should only happen for objects written before validation was in
+		// place. Don't guess: fall back to the safe default instead of
+		// treating garbage as a request for an in-place action.
+		logger.Error(errors.Errorf("unrecognized remediation strategy %q", strategy), "Falling back to Replace", "target", t.string())
+		return 0, false, nil
+	}
+
+	if _, actionPending := t.Machine.Annotations[MachineRemediateActionAnnotation]; actionPending && conditions.IsTrue(t.Machine, clusterv1.RemediationInProgressCondition) {
+		// The infrastructure provider hasn't cleared MachineRemediateActionAnnotation
+		// yet, so the previous attempt hasn't completed. MHC reconciles fire on
+		// every Node/Machine update, well inside defaultEscalationWindow, so
+		// without this guard every one of those reconciles would count as a
+		// brand new attempt and blow through escalationMaxAttempts before the
+		// host had any real chance to recover from the first one.
+		logger.V(3).Info("Remediation already in progress, waiting for provider to finish", "target", t.string())
+		return 0, true, nil
+	}
+
+	attempts, lastAttempt, window := machineRemediationAttempts(t.Machine), time.Time{}, escalationWindow(m)
+	if v, ok := t.Machine.Annotations[MachineRemediationLastAttemptAnnotation]; ok {
+		if parsed, err := time.Parse(time.RFC3339, v); err == nil {
+			lastAttempt = parsed
+		}
+	}
+	if lastAttempt.IsZero() || time.Since(lastAttempt) > window {
+		attempts = 0
+	}
+
+	if strategy == clusterv1.RemediationStrategyEscalate && attempts >= escalationMaxAttempts(m) {
+		logger.V(3).Info("Remediation attempts exhausted, escalating to Replace", "target", t.string(), "attempts", attempts)
+		conditions.MarkTrue(t.Machine, clusterv1.RemediationEscalatedCondition)
+		conditions.Delete(t.Machine, clusterv1.RemediationInProgressCondition)
+		return 0, false, nil
+	}
+	attempts++
+
+	machineAnnotations := t.Machine.GetAnnotations()
+	if machineAnnotations == nil {
+		machineAnnotations = map[string]string{}
+	}
+	machineAnnotations[MachineRemediationAttemptsAnnotation] = strconv.Itoa(attempts)
+	machineAnnotations[MachineRemediationLastAttemptAnnotation] = time.Now().Format(time.RFC3339)
+	machineAnnotations[MachineRemediationLastActionAnnotation] = string(action)
+	machineAnnotations[MachineRemediateActionAnnotation] = string(action)
+	t.Machine.SetAnnotations(machineAnnotations)
+
+	conditions.MarkTrue(t.Machine, clusterv1.RemediationInProgressCondition)
+	if err := t.patchHelper.Patch(ctx, t.Machine); err != nil {
+		return 0, true, errors.Wrapf(err, "Failed to patch machine %q for %s remediation", t.Machine.Name, action)
+	}
+
+	r.recorder.Eventf(
+		t.Machine,
+		corev1.EventTypeNormal,
+		EventMachineMarkedUnhealthy,
+		"Requested %s remediation for machine %v (attempt %d/%d)",
+		action,
+		t.string(),
+		attempts,
+		escalationMaxAttempts(m),
+	)
+
+	return 0, true, nil
+}
+
+func machineRemediationAttempts(m *clusterv1.Machine) int {
+	v, ok := m.Annotations[MachineRemediationAttemptsAnnotation]
+	if !ok {
+		return 0
+	}
+	attempts, err := strconv.Atoi(v)
+	if err != nil {
+		return 0
+	}
+	return attempts
+}
+
+func escalationMaxAttempts(mhc *clusterv1.MachineHealthCheck) int {
+	v, ok := mhc.Annotations[EscalationMaxAttemptsAnnotation]
+	if !ok {
+		return defaultEscalationMaxAttempts
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return defaultEscalationMaxAttempts
+	}
+	return n
+}
+
+func escalationWindow(mhc *clusterv1.MachineHealthCheck) time.Duration {
+	v, ok := mhc.Annotations[EscalationWindowAnnotation]
+	if !ok {
+		return defaultEscalationWindow
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return defaultEscalationWindow
+	}
+	return d
+}
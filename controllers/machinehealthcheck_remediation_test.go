@@ -0,0 +1,153 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestMachineRemediationAttempts(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(machineRemediationAttempts(&clusterv1.Machine{})).To(Equal(0))
+
+	m := &clusterv1.Machine{}
+	m.Annotations = map[string]string{MachineRemediationAttemptsAnnotation: "2"}
+	g.Expect(machineRemediationAttempts(m)).To(Equal(2))
+
+	// A malformed annotation value must not be treated as a huge attempt
+	// count; it should behave as if remediation had never been attempted.
+	m.Annotations[MachineRemediationAttemptsAnnotation] = "not-a-number"
+	g.Expect(machineRemediationAttempts(m)).To(Equal(0))
+}
+
+func TestEscalationMaxAttempts(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(escalationMaxAttempts(&clusterv1.MachineHealthCheck{})).To(Equal(defaultEscalationMaxAttempts))
+
+	mhc := &clusterv1.MachineHealthCheck{}
+	mhc.Annotations = map[string]string{EscalationMaxAttemptsAnnotation: "5"}
+	g.Expect(escalationMaxAttempts(mhc)).To(Equal(5))
+
+	for _, malformed := range []string{"not-a-number", "0", "-1"} {
+		mhc.Annotations[EscalationMaxAttemptsAnnotation] = malformed
+		g.Expect(escalationMaxAttempts(mhc)).To(Equal(defaultEscalationMaxAttempts), "value %q should fall back to the default", malformed)
+	}
+}
+
+func TestEscalationWindow(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(escalationWindow(&clusterv1.MachineHealthCheck{})).To(Equal(defaultEscalationWindow))
+
+	mhc := &clusterv1.MachineHealthCheck{}
+	mhc.Annotations = map[string]string{EscalationWindowAnnotation: "10m"}
+	g.Expect(escalationWindow(mhc)).To(Equal(10 * time.Minute))
+
+	for _, malformed := range []string{"not-a-duration", "0s", "-5m"} {
+		mhc.Annotations[EscalationWindowAnnotation] = malformed
+		g.Expect(escalationWindow(mhc)).To(Equal(defaultEscalationWindow), "value %q should fall back to the default", malformed)
+	}
+}
+
+// TestRemediateWithStrategyUnrecognizedValueFallsBack covers the review
+// concern that a malformed or unrecognized Spec.RemediationStrategy (for
+// example, a value written before the field was enum-validated) must fall
+// through to the default Replace remediation, not be silently treated as
+// PowerCycle.
+func TestRemediateWithStrategyUnrecognizedValueFallsBack(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &MachineHealthCheckReconciler{Log: logr.Discard()}
+	garbage := clusterv1.RemediationStrategyType("not-a-real-strategy")
+	m := &clusterv1.MachineHealthCheck{Spec: clusterv1.MachineHealthCheckSpec{RemediationStrategy: &garbage}}
+	target := healthCheckTarget{Machine: &clusterv1.Machine{}}
+
+	requeueAfter, handled, err := r.remediateWithStrategy(context.Background(), logr.Discard(), m, target)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(handled).To(BeFalse())
+	g.Expect(requeueAfter).To(BeZero())
+}
+
+// TestRemediateWithStrategyEscalatesWithinWindow covers the escalation state
+// machine: once a Machine has reached EscalationMaxAttemptsAnnotation
+// in-place attempts within the configured window, RemediationStrategyEscalate
+// must give up on further in-place attempts and fall through to the default
+// Replace remediation instead of retrying forever.
+func TestRemediateWithStrategyEscalatesWithinWindow(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &MachineHealthCheckReconciler{Log: logr.Discard()}
+	escalate := clusterv1.RemediationStrategyEscalate
+	mhc := &clusterv1.MachineHealthCheck{Spec: clusterv1.MachineHealthCheckSpec{RemediationStrategy: &escalate}}
+	mhc.Annotations = map[string]string{EscalationMaxAttemptsAnnotation: "1"}
+
+	machine := &clusterv1.Machine{}
+	machine.Annotations = map[string]string{
+		MachineRemediationAttemptsAnnotation:    "1",
+		MachineRemediationLastAttemptAnnotation: time.Now().Format(time.RFC3339),
+	}
+	target := healthCheckTarget{Machine: machine}
+
+	requeueAfter, handled, err := r.remediateWithStrategy(context.Background(), logr.Discard(), mhc, target)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(handled).To(BeFalse())
+	g.Expect(requeueAfter).To(BeZero())
+}
+
+// TestRemediateWithStrategySkipsReconcileWhileActionPending covers the bug
+// where every MHC reconcile of a target undergoing remediation (Node/Machine
+// updates fire well inside defaultEscalationWindow) counted as its own
+// attempt. While the infra provider hasn't cleared
+// MachineRemediateActionAnnotation and RemediationInProgressCondition is
+// still true, a reconcile must not re-count the attempt, re-stamp
+// last-attempt-time, or re-issue the remediate-action annotation.
+func TestRemediateWithStrategySkipsReconcileWhileActionPending(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &MachineHealthCheckReconciler{Log: logr.Discard()}
+	powerCycle := clusterv1.RemediationStrategyPowerCycle
+	mhc := &clusterv1.MachineHealthCheck{Spec: clusterv1.MachineHealthCheckSpec{RemediationStrategy: &powerCycle}}
+
+	lastAttempt := time.Now().Format(time.RFC3339)
+	machine := &clusterv1.Machine{}
+	machine.Annotations = map[string]string{
+		MachineRemediationAttemptsAnnotation:    "1",
+		MachineRemediationLastAttemptAnnotation: lastAttempt,
+		MachineRemediateActionAnnotation:        string(clusterv1.RemediationStrategyPowerCycle),
+	}
+	conditions.MarkTrue(machine, clusterv1.RemediationInProgressCondition)
+	target := healthCheckTarget{Machine: machine}
+
+	requeueAfter, handled, err := r.remediateWithStrategy(context.Background(), logr.Discard(), mhc, target)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(handled).To(BeTrue())
+	g.Expect(requeueAfter).To(BeZero())
+
+	// Nothing about the pending attempt's bookkeeping should have changed.
+	g.Expect(machine.Annotations[MachineRemediationAttemptsAnnotation]).To(Equal("1"))
+	g.Expect(machine.Annotations[MachineRemediationLastAttemptAnnotation]).To(Equal(lastAttempt))
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/controllers/remediation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=providerconfigs;providerconfigs/status,verbs=get;list;watch;update;patch
+
+// ProviderConfigReconciler dials (and redials on change) the gRPC endpoint
+// described by each ProviderConfig and keeps Registry in sync, so that the
+// MachineHealthCheckReconciler can resolve remediation providers by name
+// without blocking on a dial during a health check reconcile.
+type ProviderConfigReconciler struct {
+	Client client.Client
+	Log    logr.Logger
+
+	// Registry must be the same *remediation.Registry instance given to
+	// MachineHealthCheckReconciler.RemediationProviders. Updates made here
+	// are only visible to MachineHealthCheckReconciler if the two share one
+	// Registry; SetupWithManager errors out if it is nil rather than
+	// constructing a private one that would leave that reconciler's Registry
+	// stale forever.
+	Registry *remediation.Registry
+}
+
+func (r *ProviderConfigReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
+	if r.Registry == nil {
+		return errors.New("Registry must be set to the same *remediation.Registry given to MachineHealthCheckReconciler.RemediationProviders")
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&clusterv1.ProviderConfig{}).
+		WithOptions(options).
+		Complete(r)
+}
+
+func (r *ProviderConfigReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	logger := r.Log.WithValues("providerconfig", req.Name, "namespace", req.Namespace)
+
+	pc := &clusterv1.ProviderConfig{}
+	if err := r.Client.Get(ctx, req.NamespacedName, pc); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Registry.Remove(req.Name)
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to fetch ProviderConfig")
+		return ctrl.Result{}, err
+	}
+
+	if !pc.DeletionTimestamp.IsZero() {
+		r.Registry.Remove(pc.Name)
+		controllerutil.RemoveFinalizer(pc, clusterv1.ProviderConfigFinalizer)
+		return ctrl.Result{}, r.Client.Update(ctx, pc)
+	}
+
+	if !controllerutil.ContainsFinalizer(pc, clusterv1.ProviderConfigFinalizer) {
+		controllerutil.AddFinalizer(pc, clusterv1.ProviderConfigFinalizer)
+		if err := r.Client.Update(ctx, pc); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to add finalizer")
+		}
+	}
+
+	endpoint := remediation.Endpoint{
+		Address:  pc.Spec.Endpoint,
+		Insecure: pc.Spec.Insecure,
+		Timeout:  remediation.DefaultTimeout,
+	}
+	if pc.Spec.Timeout != nil {
+		endpoint.Timeout = pc.Spec.Timeout.Duration
+	}
+
+	if pc.Spec.CASecretRef != nil {
+		secret := &corev1.Secret{}
+		secretKey := client.ObjectKey{Namespace: pc.Spec.CASecretRef.Namespace, Name: pc.Spec.CASecretRef.Name}
+		if secretKey.Namespace == "" {
+			secretKey.Namespace = pc.Namespace
+		}
+		if err := r.Client.Get(ctx, secretKey, secret); err != nil {
+			logger.Error(err, "Failed to fetch CA secret for ProviderConfig")
+			return ctrl.Result{}, errors.Wrapf(err, "failed to get CA secret %q for ProviderConfig %q", secretKey, pc.Name)
+		}
+		endpoint.CACert = secret.Data["ca.crt"]
+	}
+
+	if err := r.Registry.Set(pc.Name, endpoint); err != nil {
+		logger.Error(err, "Failed to dial remediation provider", "endpoint", endpoint.Address)
+		pc.Status.Ready = false
+		pc.Status.FailureReason = err.Error()
+		if statusErr := r.Client.Status().Update(ctx, pc); statusErr != nil {
+			logger.Error(statusErr, "Failed to update ProviderConfig status")
+		}
+		return ctrl.Result{}, err
+	}
+
+	pc.Status.Ready = true
+	pc.Status.FailureReason = ""
+	return ctrl.Result{}, r.Client.Status().Update(ctx, pc)
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remediation
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	pb "sigs.k8s.io/cluster-api/controllers/remediation/proto"
+)
+
+// grpcProvider is a Provider backed by a gRPC connection to an out-of-tree
+// remediation provider.
+type grpcProvider struct {
+	client  pb.RemediatorClient
+	conn    *grpc.ClientConn
+	timeout time.Duration
+}
+
+// Endpoint describes how to reach and authenticate a remediation provider,
+// loaded from a ProviderConfig resource.
+type Endpoint struct {
+	// Address is the host:port of the provider's gRPC endpoint.
+	Address string
+
+	// CACert, when set, is used to verify the provider's server certificate
+	// instead of the host's root CA set.
+	CACert []byte
+
+	// Insecure disables TLS. It should only be used for local development.
+	Insecure bool
+
+	// Timeout bounds every ShouldRemediate/Remediate call.
+	Timeout time.Duration
+}
+
+// dial opens a gRPC connection to endpoint and wraps it as a Provider.
+func dial(endpoint Endpoint) (*grpcProvider, error) {
+	opts := []grpc.DialOption{
+		grpc.WithBlock(),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype(pb.CodecName)),
+	}
+	if endpoint.Insecure {
+		opts = append(opts, grpc.WithInsecure())
+	} else {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if len(endpoint.CACert) > 0 {
+			pool := x509.NewCertPool()
+			if ok := pool.AppendCertsFromPEM(endpoint.CACert); !ok {
+				return nil, errors.Errorf("failed to parse CA certificate for remediation provider endpoint %q", endpoint.Address)
+			}
+			tlsConfig.RootCAs = pool
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	}
+
+	dialCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(dialCtx, endpoint.Address, opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial remediation provider at %q", endpoint.Address)
+	}
+
+	timeout := endpoint.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	return &grpcProvider{
+		client:  pb.NewRemediatorClient(conn),
+		conn:    conn,
+		timeout: timeout,
+	}, nil
+}
+
+// Close closes the underlying gRPC connection.
+func (p *grpcProvider) Close() error {
+	return p.conn.Close()
+}
+
+func (p *grpcProvider) ShouldRemediate(ctx context.Context, target UnhealthyTarget) (Decision, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	resp, err := p.client.ShouldRemediate(ctx, toProto(target))
+	if err != nil {
+		return Decision{}, errors.Wrapf(err, "ShouldRemediate call failed for machine %q", target.MachineName)
+	}
+
+	return Decision{
+		Allow:   resp.Allow,
+		Reason:  resp.Reason,
+		Backoff: time.Duration(resp.BackoffSeconds) * time.Second,
+	}, nil
+}
+
+func (p *grpcProvider) Remediate(ctx context.Context, target UnhealthyTarget) (Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	resp, err := p.client.Remediate(ctx, toProto(target))
+	if err != nil {
+		return Result{}, errors.Wrapf(err, "Remediate call failed for machine %q", target.MachineName)
+	}
+
+	return Result{
+		Action:       fromProtoAction(resp.Action),
+		RequeueAfter: time.Duration(resp.RequeueAfterSeconds) * time.Second,
+	}, nil
+}
+
+func toProto(target UnhealthyTarget) *pb.UnhealthyTarget {
+	return &pb.UnhealthyTarget{
+		ClusterName:     target.ClusterName,
+		Namespace:       target.Namespace,
+		MachineName:     target.MachineName,
+		NodeName:        target.NodeName,
+		UnhealthyReason: target.UnhealthyReason,
+	}
+}
+
+func fromProtoAction(a pb.Action) Action {
+	switch a {
+	case pb.Action_DELETE:
+		return ActionDelete
+	case pb.Action_REBOOT:
+		return ActionReboot
+	case pb.Action_REIMAGE:
+		return ActionReimage
+	default:
+		return ActionIgnore
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remediation
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	pb "sigs.k8s.io/cluster-api/controllers/remediation/proto"
+)
+
+// fakeRemediator is a minimal RemediatorServer used to prove that a real
+// gRPC call round-trips through grpcProvider's wire codec end to end.
+type fakeRemediator struct {
+	gotShouldRemediate *pb.UnhealthyTarget
+	gotRemediate       *pb.UnhealthyTarget
+}
+
+func (f *fakeRemediator) ShouldRemediate(_ context.Context, in *pb.UnhealthyTarget) (*pb.ShouldRemediateResponse, error) {
+	f.gotShouldRemediate = in
+	return &pb.ShouldRemediateResponse{Allow: true, Reason: "ok"}, nil
+}
+
+func (f *fakeRemediator) Remediate(_ context.Context, in *pb.UnhealthyTarget) (*pb.RemediateResponse, error) {
+	f.gotRemediate = in
+	return &pb.RemediateResponse{Action: pb.Action_REBOOT, RequeueAfterSeconds: 30}, nil
+}
+
+// dialFake starts srv in-process over a bufconn listener and returns a
+// grpcProvider talking to it, exactly as dial() would for a real endpoint.
+func dialFake(t *testing.T, srv pb.RemediatorServer) *grpcProvider {
+	t.Helper()
+	g := NewWithT(t)
+
+	lis := bufconn.Listen(1024 * 1024)
+	t.Cleanup(func() { _ = lis.Close() })
+
+	s := grpc.NewServer()
+	pb.RegisterRemediatorServer(s, srv)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithInsecure(),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype(pb.CodecName)),
+		grpc.WithBlock(),
+	)
+	g.Expect(err).NotTo(HaveOccurred())
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return &grpcProvider{client: pb.NewRemediatorClient(conn), conn: conn, timeout: time.Second}
+}
+
+func TestGRPCProviderRoundTrip(t *testing.T) {
+	g := NewWithT(t)
+
+	fake := &fakeRemediator{}
+	p := dialFake(t, fake)
+
+	target := UnhealthyTarget{
+		ClusterName:     "my-cluster",
+		Namespace:       "my-namespace",
+		MachineName:     "my-machine",
+		NodeName:        "my-node",
+		UnhealthyReason: "NodeNotReady",
+	}
+
+	decision, err := p.ShouldRemediate(context.Background(), target)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(decision.Allow).To(BeTrue())
+	g.Expect(decision.Reason).To(Equal("ok"))
+	g.Expect(fake.gotShouldRemediate.MachineName).To(Equal("my-machine"))
+	g.Expect(fake.gotShouldRemediate.UnhealthyReason).To(Equal("NodeNotReady"))
+
+	result, err := p.Remediate(context.Background(), target)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.Action).To(Equal(ActionReboot))
+	g.Expect(result.RequeueAfter).To(Equal(30 * time.Second))
+	g.Expect(fake.gotRemediate.ClusterName).To(Equal("my-cluster"))
+}
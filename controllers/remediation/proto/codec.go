@@ -0,0 +1,41 @@
+package proto
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// CodecName is the gRPC content-subtype used for the Remediator service.
+// It is registered as a grpc/encoding.Codec below, and grpc_provider.go
+// dials with grpc.CallContentSubtype(CodecName) so that every call (and,
+// on the server side, every incoming request carrying it) is routed
+// through wireCodec instead of the default proto.Message-based codec.
+const CodecName = "mhcremediation"
+
+func init() {
+	encoding.RegisterCodec(wireCodec{})
+}
+
+// wireCodec marshals the message types in this package using their
+// hand-written Marshal/Unmarshal methods (see remediation.pb.go) rather
+// than requiring them to satisfy proto.Message.
+type wireCodec struct{}
+
+func (wireCodec) Name() string { return CodecName }
+
+func (wireCodec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(wireMessage)
+	if !ok {
+		return nil, fmt.Errorf("proto: %T does not implement wireMessage", v)
+	}
+	return m.Marshal()
+}
+
+func (wireCodec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(wireMessage)
+	if !ok {
+		return fmt.Errorf("proto: %T does not implement wireMessage", v)
+	}
+	return m.Unmarshal(data)
+}
@@ -0,0 +1,250 @@
+// Package proto holds the wire types for the v1alpha1.Remediator service
+// defined in remediation.proto.
+//
+// These types are hand-maintained rather than produced by protoc, because
+// this environment does not have a protobuf toolchain available. Rather
+// than asserting these structs to google.golang.org/protobuf's
+// proto.Message (which they cannot satisfy without full reflection
+// support), each message implements Marshal/Unmarshal directly against
+// google.golang.org/protobuf/encoding/protowire using the same field
+// numbers and wire types as remediation.proto, and is paired with the gRPC
+// codec in codec.go that calls those methods instead of going through
+// proto.Marshal. The bytes on the wire are ordinary protobuf, so any
+// client or server generated from remediation.proto by a real protoc in
+// another language interoperates with this package unchanged.
+//
+// If this package is ever regenerated with protoc-gen-go, this file
+// should be replaced with its output and codec.go removed in favor of the
+// gRPC-Go default proto codec.
+package proto
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Action is the remediation action a provider took or is requesting.
+type Action int32
+
+const (
+	Action_ACTION_UNSPECIFIED Action = 0
+	Action_DELETE             Action = 1
+	Action_REBOOT             Action = 2
+	Action_REIMAGE            Action = 3
+	Action_IGNORE             Action = 4
+)
+
+var actionName = map[Action]string{
+	Action_ACTION_UNSPECIFIED: "ACTION_UNSPECIFIED",
+	Action_DELETE:             "DELETE",
+	Action_REBOOT:             "REBOOT",
+	Action_REIMAGE:            "REIMAGE",
+	Action_IGNORE:             "IGNORE",
+}
+
+func (a Action) String() string {
+	if name, ok := actionName[a]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+// wireMessage is implemented by every message in this package and is what
+// codec.go requires to marshal/unmarshal a gRPC request or response.
+type wireMessage interface {
+	Marshal() ([]byte, error)
+	Unmarshal([]byte) error
+}
+
+// UnhealthyTarget describes a Machine that failed its MachineHealthCheck.
+type UnhealthyTarget struct {
+	ClusterName     string `json:"cluster_name,omitempty"`
+	Namespace       string `json:"namespace,omitempty"`
+	MachineName     string `json:"machine_name,omitempty"`
+	NodeName        string `json:"node_name,omitempty"`
+	UnhealthyReason string `json:"unhealthy_reason,omitempty"`
+}
+
+// Marshal encodes m as the protobuf wire format described by
+// remediation.proto's UnhealthyTarget message.
+func (m *UnhealthyTarget) Marshal() ([]byte, error) {
+	var b []byte
+	b = appendString(b, 1, m.ClusterName)
+	b = appendString(b, 2, m.Namespace)
+	b = appendString(b, 3, m.MachineName)
+	b = appendString(b, 4, m.NodeName)
+	b = appendString(b, 5, m.UnhealthyReason)
+	return b, nil
+}
+
+// Unmarshal decodes b, previously produced by Marshal, into m.
+func (m *UnhealthyTarget) Unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch num {
+		case 1:
+			v, n, err := consumeString(typ, b)
+			m.ClusterName = v
+			return n, err
+		case 2:
+			v, n, err := consumeString(typ, b)
+			m.Namespace = v
+			return n, err
+		case 3:
+			v, n, err := consumeString(typ, b)
+			m.MachineName = v
+			return n, err
+		case 4:
+			v, n, err := consumeString(typ, b)
+			m.NodeName = v
+			return n, err
+		case 5:
+			v, n, err := consumeString(typ, b)
+			m.UnhealthyReason = v
+			return n, err
+		default:
+			return skipField(num, typ, b)
+		}
+	})
+}
+
+// ShouldRemediateResponse is the result of a ShouldRemediate call.
+type ShouldRemediateResponse struct {
+	Allow          bool   `json:"allow,omitempty"`
+	Reason         string `json:"reason,omitempty"`
+	BackoffSeconds int64  `json:"backoff_seconds,omitempty"`
+}
+
+func (m *ShouldRemediateResponse) Marshal() ([]byte, error) {
+	var b []byte
+	b = appendBool(b, 1, m.Allow)
+	b = appendString(b, 2, m.Reason)
+	b = appendVarint(b, 3, uint64(m.BackoffSeconds))
+	return b, nil
+}
+
+func (m *ShouldRemediateResponse) Unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch num {
+		case 1:
+			v, n, err := consumeVarint(typ, b)
+			m.Allow = v != 0
+			return n, err
+		case 2:
+			v, n, err := consumeString(typ, b)
+			m.Reason = v
+			return n, err
+		case 3:
+			v, n, err := consumeVarint(typ, b)
+			m.BackoffSeconds = int64(v)
+			return n, err
+		default:
+			return skipField(num, typ, b)
+		}
+	})
+}
+
+// RemediateResponse is the result of a Remediate call.
+type RemediateResponse struct {
+	Action              Action `json:"action,omitempty"`
+	RequeueAfterSeconds int64  `json:"requeue_after_seconds,omitempty"`
+}
+
+func (m *RemediateResponse) Marshal() ([]byte, error) {
+	var b []byte
+	b = appendVarint(b, 1, uint64(m.Action))
+	b = appendVarint(b, 2, uint64(m.RequeueAfterSeconds))
+	return b, nil
+}
+
+func (m *RemediateResponse) Unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch num {
+		case 1:
+			v, n, err := consumeVarint(typ, b)
+			m.Action = Action(v)
+			return n, err
+		case 2:
+			v, n, err := consumeVarint(typ, b)
+			m.RequeueAfterSeconds = int64(v)
+			return n, err
+		default:
+			return skipField(num, typ, b)
+		}
+	})
+}
+
+// --- shared protowire helpers ---
+
+func appendString(b []byte, num protowire.Number, v string) []byte {
+	if v == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func appendBool(b []byte, num protowire.Number, v bool) []byte {
+	if !v {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, 1)
+}
+
+func consumeString(typ protowire.Type, b []byte) (string, int, error) {
+	if typ != protowire.BytesType {
+		return "", 0, fmt.Errorf("proto: unexpected wire type %v for string field", typ)
+	}
+	v, n := protowire.ConsumeString(b)
+	if n < 0 {
+		return "", 0, protowire.ParseError(n)
+	}
+	return v, n, nil
+}
+
+func consumeVarint(typ protowire.Type, b []byte) (uint64, int, error) {
+	if typ != protowire.VarintType {
+		return 0, 0, fmt.Errorf("proto: unexpected wire type %v for varint field", typ)
+	}
+	v, n := protowire.ConsumeVarint(b)
+	if n < 0 {
+		return 0, 0, protowire.ParseError(n)
+	}
+	return v, n, nil
+}
+
+func skipField(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+	n := protowire.ConsumeFieldValue(num, typ, b)
+	if n < 0 {
+		return 0, protowire.ParseError(n)
+	}
+	return n, nil
+}
+
+// consumeFields walks every (field number, wire type, value) tuple in b,
+// handing the remaining bytes to field for each one. field returns how many
+// bytes it consumed from its argument.
+func consumeFields(b []byte, field func(num protowire.Number, typ protowire.Type, b []byte) (int, error)) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		n, err := field(num, typ, b)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+	}
+	return nil
+}
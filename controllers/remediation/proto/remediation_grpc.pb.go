@@ -0,0 +1,97 @@
+// remediation_grpc.pb.go hand-maintains the gRPC client/server stubs for
+// the v1alpha1.Remediator service defined in remediation.proto (see the
+// package doc in remediation.pb.go for why this isn't protoc output in
+// this environment). Its shape otherwise mirrors what protoc-gen-go-grpc
+// would produce.
+package proto
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// RemediatorClient is the client API for the Remediator service.
+type RemediatorClient interface {
+	ShouldRemediate(ctx context.Context, in *UnhealthyTarget, opts ...grpc.CallOption) (*ShouldRemediateResponse, error)
+	Remediate(ctx context.Context, in *UnhealthyTarget, opts ...grpc.CallOption) (*RemediateResponse, error)
+}
+
+type remediatorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewRemediatorClient returns a RemediatorClient backed by the given connection.
+func NewRemediatorClient(cc grpc.ClientConnInterface) RemediatorClient {
+	return &remediatorClient{cc}
+}
+
+func (c *remediatorClient) ShouldRemediate(ctx context.Context, in *UnhealthyTarget, opts ...grpc.CallOption) (*ShouldRemediateResponse, error) {
+	out := new(ShouldRemediateResponse)
+	if err := c.cc.Invoke(ctx, "/v1alpha1.Remediator/ShouldRemediate", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remediatorClient) Remediate(ctx context.Context, in *UnhealthyTarget, opts ...grpc.CallOption) (*RemediateResponse, error) {
+	out := new(RemediateResponse)
+	if err := c.cc.Invoke(ctx, "/v1alpha1.Remediator/Remediate", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RemediatorServer is the server API for the Remediator service.
+// Out-of-tree remediation providers implement this interface.
+type RemediatorServer interface {
+	ShouldRemediate(context.Context, *UnhealthyTarget) (*ShouldRemediateResponse, error)
+	Remediate(context.Context, *UnhealthyTarget) (*RemediateResponse, error)
+}
+
+// RegisterRemediatorServer registers srv with s so it serves the
+// v1alpha1.Remediator service.
+func RegisterRemediatorServer(s grpc.ServiceRegistrar, srv RemediatorServer) {
+	s.RegisterService(&remediatorServiceDesc, srv)
+}
+
+func remediatorShouldRemediateHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnhealthyTarget)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemediatorServer).ShouldRemediate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/v1alpha1.Remediator/ShouldRemediate"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemediatorServer).ShouldRemediate(ctx, req.(*UnhealthyTarget))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func remediatorRemediateHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnhealthyTarget)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemediatorServer).Remediate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/v1alpha1.Remediator/Remediate"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemediatorServer).Remediate(ctx, req.(*UnhealthyTarget))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var remediatorServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v1alpha1.Remediator",
+	HandlerType: (*RemediatorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "ShouldRemediate", Handler: remediatorShouldRemediateHandler},
+		{MethodName: "Remediate", Handler: remediatorRemediateHandler},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "remediation.proto",
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package remediation implements the out-of-tree remediation provider
+// contract used by the MachineHealthCheck controller. A provider is
+// resolved by name from the MachineHealthCheck's remediation provider
+// annotation and reached over gRPC, allowing remediation decisions and
+// actions to be delegated to logic that understands the underlying
+// infrastructure (e.g. bare-metal power management) instead of always
+// deleting the Machine.
+package remediation
+
+import (
+	"context"
+	"time"
+)
+
+// Action is the remediation action a provider took or is requesting be
+// taken on an unhealthy target.
+type Action string
+
+const (
+	// ActionDelete indicates the provider wants the Machine deleted, mirroring
+	// the default MachineHealthCheck behavior.
+	ActionDelete Action = "Delete"
+
+	// ActionReboot indicates the provider rebooted (or is rebooting) the
+	// underlying host without deleting the Machine.
+	ActionReboot Action = "Reboot"
+
+	// ActionReimage indicates the provider reimaged the underlying host
+	// without deleting the Machine.
+	ActionReimage Action = "Reimage"
+
+	// ActionIgnore indicates the provider took no action, e.g. because it is
+	// already remediating the target or has decided the target is healthy.
+	ActionIgnore Action = "Ignore"
+)
+
+// UnhealthyTarget is the information handed to a remediation provider about
+// a Machine that failed its MachineHealthCheck.
+type UnhealthyTarget struct {
+	ClusterName     string
+	Namespace       string
+	MachineName     string
+	NodeName        string
+	UnhealthyReason string
+}
+
+// Decision is the result of asking a provider whether a target may be
+// remediated right now.
+type Decision struct {
+	// Allow is false if the provider's own circuit-breaking logic is
+	// preventing remediation of this target at this time.
+	Allow bool
+
+	// Reason explains the decision, surfaced in events and conditions.
+	Reason string
+
+	// Backoff, when Allow is false, is how long the reconciler should wait
+	// before asking again.
+	Backoff time.Duration
+}
+
+// Result is the outcome of asking a provider to remediate a target.
+type Result struct {
+	// Action is the remediation action the provider took.
+	Action Action
+
+	// RequeueAfter asks the reconciler to check back on this target after
+	// the given duration, e.g. to poll for a reboot to complete.
+	RequeueAfter time.Duration
+}
+
+// Provider is implemented by out-of-tree remediation providers reached over
+// gRPC, and is the interface the MachineHealthCheckReconciler programs
+// against.
+type Provider interface {
+	// ShouldRemediate decides whether target may be remediated right now.
+	ShouldRemediate(ctx context.Context, target UnhealthyTarget) (Decision, error)
+
+	// Remediate performs (or requests) remediation of target.
+	Remediate(ctx context.Context, target UnhealthyTarget) (Result, error)
+}
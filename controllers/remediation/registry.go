@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remediation
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Registry resolves remediation provider names to dialed Providers. It is
+// shared between the MachineHealthCheckReconciler, which only ever reads
+// from it, and the ProviderConfigReconciler, which keeps it up to date as
+// ProviderConfig resources are created, updated and deleted.
+type Registry struct {
+	Log logr.Logger
+
+	mu        sync.RWMutex
+	providers map[string]*grpcProvider
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry(log logr.Logger) *Registry {
+	return &Registry{
+		Log:       log,
+		providers: make(map[string]*grpcProvider),
+	}
+}
+
+// Get returns the Provider registered under name, if any.
+func (r *Registry) Get(name string) (Provider, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.providers[name]
+	return p, ok
+}
+
+// Set dials endpoint and registers it under name, replacing and closing any
+// previously dialed connection for the same name. It is safe to call
+// repeatedly as ProviderConfig resources are reconciled.
+func (r *Registry) Set(name string, endpoint Endpoint) error {
+	p, err := dial(endpoint)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.providers[name]; ok {
+		if err := old.Close(); err != nil {
+			r.Log.Error(err, "Failed to close stale remediation provider connection", "provider", name)
+		}
+	}
+	r.providers[name] = p
+	return nil
+}
+
+// Remove closes and removes the provider registered under name, if any.
+func (r *Registry) Remove(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.providers[name]
+	if !ok {
+		return
+	}
+	if err := p.Close(); err != nil {
+		r.Log.Error(err, "Failed to close remediation provider connection", "provider", name)
+	}
+	delete(r.providers, name)
+}
+
+// DefaultTimeout is used when a ProviderConfig does not specify one.
+const DefaultTimeout = 5 * time.Second
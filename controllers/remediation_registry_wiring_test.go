@@ -0,0 +1,68 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/cluster-api/controllers/remediation"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+// TestProviderConfigReconcilerSetupWithManagerRequiresRegistry asserts that
+// ProviderConfigReconciler refuses to silently construct its own Registry.
+// A private Registry would never receive MachineHealthCheckReconciler's
+// lookups, so the two reconcilers must be wired to the same instance by
+// whoever constructs them.
+func TestProviderConfigReconcilerSetupWithManagerRequiresRegistry(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &ProviderConfigReconciler{Log: logr.Discard()}
+	err := r.SetupWithManager(nil, controller.Options{})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(r.Registry).To(BeNil())
+}
+
+// TestMachineHealthCheckReconcilerSetupWithManagerRequiresRegistry mirrors
+// TestProviderConfigReconcilerSetupWithManagerRequiresRegistry for the other
+// side of the same sharing requirement.
+func TestMachineHealthCheckReconcilerSetupWithManagerRequiresRegistry(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &MachineHealthCheckReconciler{Log: logr.Discard()}
+	err := r.SetupWithManager(nil, controller.Options{})
+	g.Expect(err).To(HaveOccurred())
+}
+
+// TestSharedRegistryVisibleToBothReconcilers checks that a single
+// *remediation.Registry assigned to both reconcilers, as SetupWithManager
+// now requires, is the identical instance on both, so that whatever
+// ProviderConfigReconciler sets in it is what MachineHealthCheckReconciler
+// reads, without a second Registry or dial.
+func TestSharedRegistryVisibleToBothReconcilers(t *testing.T) {
+	g := NewWithT(t)
+
+	shared := remediation.NewRegistry(logr.Discard())
+	mhcReconciler := &MachineHealthCheckReconciler{Log: logr.Discard(), RemediationProviders: shared}
+	pcReconciler := &ProviderConfigReconciler{Log: logr.Discard(), Registry: shared}
+
+	g.Expect(mhcReconciler.RemediationProviders).To(BeIdenticalTo(pcReconciler.Registry))
+}